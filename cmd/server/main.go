@@ -0,0 +1,556 @@
+// Command server runs the X bot HTTP API: tweet replies/actions, DMs,
+// chunked media upload, and per-account session management.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hichbnm/X_bot_api/internal/auth"
+	"github.com/hichbnm/X_bot_api/internal/dm"
+	"github.com/hichbnm/X_bot_api/internal/media"
+	"github.com/hichbnm/X_bot_api/internal/oauth1"
+	"github.com/hichbnm/X_bot_api/internal/sessions"
+	"github.com/hichbnm/X_bot_api/internal/tweets"
+)
+
+type ReplyRequest struct {
+	TweetText string `json:"tweet_text"`
+	ReplyURL  string `json:"reply_to_url"`
+}
+
+func main() {
+	login := flag.Bool("login", false, "run the interactive Chrome login flow and save auth_token.txt/guest_id.txt instead of starting the server")
+	flag.Parse()
+
+	if *login {
+		if err := auth.RunInteractiveLogin(); err != nil {
+			fmt.Println("❌ Login flow failed:", err)
+			return
+		}
+		return
+	}
+
+	http.HandleFunc("/reply-tweet", handleReplyTweet)
+	http.HandleFunc("/dm/send", handleDMSend)
+	http.HandleFunc("/dm/inbox", handleDMInbox)
+	http.HandleFunc("/dm/conversation/", handleDMConversation)
+	http.HandleFunc("/dm/events", handleDMEvents)
+	http.HandleFunc("/accounts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			handleCreateAccount(w, r)
+			return
+		}
+		handleListAccounts(w, r)
+	})
+	http.HandleFunc("/accounts/", handleDeleteAccount)
+	http.HandleFunc("/tweets/create", handleTweetCreate)
+	http.HandleFunc("/tweets/reply", handleTweetReply)
+	http.HandleFunc("/tweets/quote", handleTweetQuote)
+	http.HandleFunc("/tweets/retweet", tweetIDAction((*tweets.Service).Retweet))
+	http.HandleFunc("/tweets/unretweet", tweetIDAction((*tweets.Service).Unretweet))
+	http.HandleFunc("/tweets/favorite", tweetIDAction((*tweets.Service).Favorite))
+	http.HandleFunc("/tweets/unfavorite", tweetIDAction((*tweets.Service).Unfavorite))
+	http.HandleFunc("/tweets/delete", tweetIDAction((*tweets.Service).Delete))
+	http.HandleFunc("/tweets/lookup", tweetIDAction((*tweets.Service).Lookup))
+	http.HandleFunc("/users/", handleUserByScreenName)
+	http.HandleFunc("/media/upload", handleMediaUpload)
+
+	sessions.StartRefreshJob()
+
+	fmt.Println("🚀 API running on http://localhost:8099")
+	http.ListenAndServe(":8099", nil)
+}
+
+// Handler
+func handleReplyTweet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var req ReplyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	tweetID := extractTweetID(req.ReplyURL)
+	if tweetID == "" {
+		http.Error(w, "Invalid reply_to_url", http.StatusBadRequest)
+		return
+	}
+
+	// Post reply through the same TweetService /tweets/reply uses; this
+	// route just keeps the older {"response": "..."} envelope shape.
+	_, respBody, err := tweets.NewService(session).Reply(req.TweetText+"\n", tweetID, nil)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(fmt.Sprintf(`{"response": %q}`, fmt.Sprintf(`{"error":%q}`, err.Error()))))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(fmt.Sprintf(`{"response": %q}`, string(respBody))))
+}
+
+// Extract Tweet ID
+func extractTweetID(url string) string {
+	re := regexp.MustCompile(`status/(\d+)`)
+	match := re.FindStringSubmatch(url)
+	if len(match) > 1 {
+		return match[1]
+	}
+	return ""
+}
+
+// dmSendRequest is the body accepted by POST /dm/send.
+type dmSendRequest struct {
+	RecipientID string `json:"recipient_id"`
+	Text        string `json:"text"`
+	MediaID     string `json:"media_id,omitempty"`
+}
+
+func handleDMSend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req dmSendRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	msg, err := dm.NewClient(session).SendMessage(req.RecipientID, req.Text, req.MediaID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(msg)
+}
+
+func handleDMInbox(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	conversations, cursor, err := dm.NewClient(session).Inbox()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"conversations": conversations,
+		"cursor":        cursor,
+	})
+}
+
+func handleDMConversation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	conversationID := strings.TrimPrefix(r.URL.Path, "/dm/conversation/")
+	if conversationID == "" {
+		http.Error(w, "Missing conversation id", http.StatusBadRequest)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	messages, err := dm.NewClient(session).Conversation(conversationID, r.URL.Query().Get("max_id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"messages": messages})
+}
+
+func handleDMEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	events, nextCursor, err := dm.NewClient(session).PollEvents(r.URL.Query().Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"events": events,
+		"cursor": nextCursor,
+	})
+}
+
+// createAccountRequest is the body accepted by POST /accounts. Setting
+// auth_token registers a "cookie"-mode account (the default, driven by
+// FetchAccountCookies/chromedp); setting the four oauth1_* fields instead
+// registers an "oauth1"-mode account, which needs no browser automation.
+type createAccountRequest struct {
+	AccountID string `json:"account_id"`
+	AuthToken string `json:"auth_token,omitempty"`
+
+	OAuth1ConsumerKey       string `json:"oauth1_consumer_key,omitempty"`
+	OAuth1ConsumerSecret    string `json:"oauth1_consumer_secret,omitempty"`
+	OAuth1AccessToken       string `json:"oauth1_access_token,omitempty"`
+	OAuth1AccessTokenSecret string `json:"oauth1_access_token_secret,omitempty"`
+}
+
+func (req createAccountRequest) hasOAuth1Creds() bool {
+	return req.OAuth1ConsumerKey != "" && req.OAuth1ConsumerSecret != "" &&
+		req.OAuth1AccessToken != "" && req.OAuth1AccessTokenSecret != ""
+}
+
+func handleCreateAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req createAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AccountID == "" {
+		http.Error(w, "account_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.AuthToken == "" && !req.hasOAuth1Creds() {
+		http.Error(w, "either auth_token or all four oauth1_* fields are required", http.StatusBadRequest)
+		return
+	}
+	if req.AuthToken != "" && req.hasOAuth1Creds() {
+		http.Error(w, "auth_token and oauth1_* fields are mutually exclusive", http.StatusBadRequest)
+		return
+	}
+
+	repo, err := sessions.EnsureRepo()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	var session *sessions.Session
+
+	if req.hasOAuth1Creds() {
+		session = &sessions.Session{
+			AccountID: req.AccountID,
+			AuthMode:  "oauth1",
+			OAuth1: &oauth1.Credentials{
+				ConsumerKey:       req.OAuth1ConsumerKey,
+				ConsumerSecret:    req.OAuth1ConsumerSecret,
+				AccessToken:       req.OAuth1AccessToken,
+				AccessTokenSecret: req.OAuth1AccessTokenSecret,
+			},
+			LastUsed: now,
+		}
+	} else {
+		ct0, guestID, err := sessions.FetchAccountCookies(req.AuthToken)
+		if err != nil {
+			http.Error(w, "login helper failed: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		bearerToken, err := auth.GetBearerToken()
+		if err != nil {
+			http.Error(w, "failed to get bearer token: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		session = &sessions.Session{
+			AccountID:       req.AccountID,
+			AuthMode:        "cookie",
+			AuthToken:       req.AuthToken,
+			GuestID:         guestID,
+			CT0:             ct0,
+			CT0FetchedAt:    now,
+			BearerToken:     bearerToken,
+			BearerFetchedAt: now,
+			UserAgent:       auth.RandomUserAgent(),
+			LastUsed:        now,
+		}
+	}
+
+	if err := repo.Save(session); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"account_id": session.AccountID})
+}
+
+func handleListAccounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	repo, err := sessions.EnsureRepo()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ids, err := repo.List()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string][]string{"accounts": ids})
+}
+
+func handleDeleteAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Only DELETE allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	accountID := strings.TrimPrefix(r.URL.Path, "/accounts/")
+	if accountID == "" {
+		http.Error(w, "Missing account id", http.StatusBadRequest)
+		return
+	}
+
+	repo, err := sessions.EnsureRepo()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := repo.Delete(accountID); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tweetActionRequest is the body every single-tweet-id /tweets/* route accepts.
+type tweetActionRequest struct {
+	TweetID string `json:"tweet_id"`
+}
+
+// createTweetRequest is the body accepted by /tweets/create, /tweets/reply,
+// and /tweets/quote.
+type createTweetRequest struct {
+	Text      string   `json:"text"`
+	InReplyTo string   `json:"in_reply_to,omitempty"`
+	QuotedURL string   `json:"quoted_url,omitempty"`
+	MediaIDs  []string `json:"media_ids,omitempty"`
+}
+
+func writeUpstream(w http.ResponseWriter, status int, body []byte, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func handleTweetCreate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var req createTweetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	status, body, err := tweets.NewService(session).Create(req.Text, req.MediaIDs)
+	writeUpstream(w, status, body, err)
+}
+
+func handleTweetReply(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var req createTweetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	status, body, err := tweets.NewService(session).Reply(req.Text, req.InReplyTo, req.MediaIDs)
+	writeUpstream(w, status, body, err)
+}
+
+func handleTweetQuote(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var req createTweetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	status, body, err := tweets.NewService(session).Quote(req.Text, req.QuotedURL)
+	writeUpstream(w, status, body, err)
+}
+
+// tweetIDAction wires up a /tweets/* route whose body is just {"tweet_id":"..."}.
+func tweetIDAction(action func(*tweets.Service, string) (int, []byte, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		session, err := sessions.ForRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var req tweetActionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.TweetID == "" {
+			http.Error(w, "tweet_id is required", http.StatusBadRequest)
+			return
+		}
+
+		status, body, err := action(tweets.NewService(session), req.TweetID)
+		writeUpstream(w, status, body, err)
+	}
+}
+
+func handleUserByScreenName(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	handle := strings.TrimPrefix(r.URL.Path, "/users/")
+	if handle == "" {
+		http.Error(w, "Missing screen name", http.StatusBadRequest)
+		return
+	}
+
+	status, body, err := tweets.NewService(session).UserByScreenName(handle)
+	writeUpstream(w, status, body, err)
+}
+
+func handleMediaUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	session, err := sessions.ForRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	file, header, err := r.FormFile("media")
+	if err != nil {
+		http.Error(w, "Missing media file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read upload", http.StatusBadRequest)
+		return
+	}
+
+	forDM := r.FormValue("for_dm") == "true"
+	mediaType, category := media.CategoryForContentType(header.Header.Get("Content-Type"), forDM)
+	if explicit := r.FormValue("media_category"); explicit != "" {
+		category = explicit
+	}
+
+	mediaID, expiresAfterSecs, err := media.NewUploader(session).Upload(data, mediaType, category)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"media_id_string":    mediaID,
+		"expires_after_secs": expiresAfterSecs,
+	})
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHasOAuth1Creds(t *testing.T) {
+	full := createAccountRequest{
+		OAuth1ConsumerKey:       "ck",
+		OAuth1ConsumerSecret:    "cs",
+		OAuth1AccessToken:       "at",
+		OAuth1AccessTokenSecret: "ats",
+	}
+	if !full.hasOAuth1Creds() {
+		t.Error("hasOAuth1Creds() = false with all four fields set, want true")
+	}
+
+	partial := full
+	partial.OAuth1AccessTokenSecret = ""
+	if partial.hasOAuth1Creds() {
+		t.Error("hasOAuth1Creds() = true with one field missing, want false")
+	}
+
+	if (createAccountRequest{}).hasOAuth1Creds() {
+		t.Error("hasOAuth1Creds() = true on the zero value, want false")
+	}
+}
+
+func TestHandleCreateAccountRejectsWrongMethod(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/accounts", nil)
+	w := httptest.NewRecorder()
+
+	handleCreateAccount(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestHandleCreateAccountRejectsMissingAccountID(t *testing.T) {
+	body := bytes.NewBufferString(`{"auth_token":"tok"}`)
+	req := httptest.NewRequest(http.MethodPost, "/accounts", body)
+	w := httptest.NewRecorder()
+
+	handleCreateAccount(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleCreateAccountRejectsMissingCreds(t *testing.T) {
+	body := bytes.NewBufferString(`{"account_id":"demo"}`)
+	req := httptest.NewRequest(http.MethodPost, "/accounts", body)
+	w := httptest.NewRecorder()
+
+	handleCreateAccount(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleCreateAccountRejectsMutuallyExclusiveCreds(t *testing.T) {
+	body := bytes.NewBufferString(`{
+		"account_id": "demo",
+		"auth_token": "tok",
+		"oauth1_consumer_key": "ck",
+		"oauth1_consumer_secret": "cs",
+		"oauth1_access_token": "at",
+		"oauth1_access_token_secret": "ats"
+	}`)
+	req := httptest.NewRequest(http.MethodPost, "/accounts", body)
+	w := httptest.NewRecorder()
+
+	handleCreateAccount(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
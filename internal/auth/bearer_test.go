@@ -0,0 +1,63 @@
+package auth
+
+import "testing"
+
+func TestChunkURLPatternFindsMainAndServiceworkerBundles(t *testing.T) {
+	html := `<html><head>
+<link rel="preload" href="https://abs.twimg.com/responsive-web/client-web/main.abc123ef.js" as="script">
+<link rel="preload" href="https://abs.twimg.com/responsive-web/client-web-legacy/serviceworker.0f1e2d3c.js" as="script">
+<link rel="preload" href="https://abs.twimg.com/responsive-web/client-web/vendor.deadbeef.js" as="script">
+</head></html>`
+
+	got := chunkURLPattern.FindAllString(html, -1)
+	want := []string{
+		"https://abs.twimg.com/responsive-web/client-web/main.abc123ef.js",
+		"https://abs.twimg.com/responsive-web/client-web-legacy/serviceworker.0f1e2d3c.js",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("chunkURLPattern.FindAllString = %v, want %v", got, want)
+	}
+	for i, url := range want {
+		if got[i] != url {
+			t.Errorf("match %d = %q, want %q", i, got[i], url)
+		}
+	}
+}
+
+func TestChunkURLPatternNoMatch(t *testing.T) {
+	html := `<html><head><link href="https://abs.twimg.com/responsive-web/client-web/vendor.deadbeef.js"></head></html>`
+
+	if got := chunkURLPattern.FindAllString(html, -1); len(got) != 0 {
+		t.Errorf("chunkURLPattern.FindAllString = %v, want no matches", got)
+	}
+}
+
+func TestBearerTokenPatternFindsEmbeddedToken(t *testing.T) {
+	token := "AAAAAAAAAAAAAAAAAAAAAMLheAAAAAAA0%2BuSeid%2BARDFDtfoCYWvfgz%2FtR9dE2zL9kQwNpXsZcVbTnMhYgRjKoFaP1uW"
+	body := `!function(){a.defaultProps={bearerToken:"` + token + `"}}();`
+
+	got := bearerTokenPattern.FindString(body)
+	if got != token {
+		t.Errorf("bearerTokenPattern.FindString = %q, want %q", got, token)
+	}
+}
+
+func TestBearerTokenPatternNoMatch(t *testing.T) {
+	body := `!function(){a.defaultProps={bearerToken:"tooshort"}}();`
+
+	if got := bearerTokenPattern.FindString(body); got != "" {
+		t.Errorf("bearerTokenPattern.FindString = %q, want no match", got)
+	}
+}
+
+func TestRandomUserAgentReturnsKnownAgent(t *testing.T) {
+	got := RandomUserAgent()
+
+	for _, ua := range userAgentPool {
+		if got == ua {
+			return
+		}
+	}
+	t.Errorf("RandomUserAgent() = %q, not found in userAgentPool", got)
+}
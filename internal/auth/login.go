@@ -1,4 +1,4 @@
-package main
+package auth
 
 import (
 	"context"
@@ -10,7 +10,11 @@ import (
 	"github.com/chromedp/chromedp"
 )
 
-func main() {
+// RunInteractiveLogin opens a GUI Chrome window against the X login page and
+// waits for a human to log in, then saves the resulting auth_token/guest_id
+// cookies to auth_token.txt/guest_id.txt. It's the manual fallback for
+// accounts that can't go through sessions.FetchAccountCookies unattended.
+func RunInteractiveLogin() error {
 	// Launch Chrome with GUI (not headless)
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
 		chromedp.Flag("headless", false),
@@ -25,19 +29,19 @@ func main() {
 
 	// Start Chrome
 	if err := chromedp.Run(ctx); err != nil {
-		panic(err)
+		return err
 	}
 
 	// Enable network
 	if err := chromedp.Run(ctx, network.Enable()); err != nil {
-		panic(err)
+		return err
 	}
 
 	fmt.Println("🚀 Opening Twitter login page... Please log in manually.")
 
 	// Navigate to Twitter login page
 	if err := chromedp.Run(ctx, chromedp.Navigate("https://x.com/login")); err != nil {
-		panic(err)
+		return err
 	}
 
 	fmt.Println("⏳ Waiting for auth_token and guest_id cookies after login...")
@@ -76,13 +80,14 @@ func main() {
 
 	// Save auth_token to file
 	if err := os.WriteFile("auth_token.txt", []byte(authToken), 0644); err != nil {
-		panic(err)
+		return err
 	}
 	fmt.Println("💾 Auth token saved to auth_token.txt")
 
 	// Save guest_id to file
 	if err := os.WriteFile("guest_id.txt", []byte(guestID), 0644); err != nil {
-		panic(err)
+		return err
 	}
 	fmt.Println("💾 Guest ID saved to guest_id.txt")
+	return nil
 }
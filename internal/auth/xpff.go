@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// XPFFHeaderGenerator builds the X-Xp-Forwarded-For header x.com expects on
+// every authenticated request: an AES-GCM encrypted blob of browser
+// fingerprint fields, keyed off BaseKey and the caller's guest id.
+type XPFFHeaderGenerator struct {
+	BaseKey string
+}
+
+// deriveXPFFKey derives the AES key using SHA256(baseKey + guestID).
+func (x *XPFFHeaderGenerator) deriveXPFFKey(guestID string) []byte {
+	combined := x.BaseKey + guestID
+	hash := sha256.Sum256([]byte(combined))
+	return hash[:]
+}
+
+// GenerateXPFF encrypts plaintext into the hex string x.com expects.
+func (x *XPFFHeaderGenerator) GenerateXPFF(plaintext, guestID string) (string, error) {
+	key := x.deriveXPFFKey(guestID)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, 12)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext := aesgcm.Seal(nil, nonce, []byte(plaintext), nil)
+	result := append(nonce, ciphertext...)
+
+	return hex.EncodeToString(result), nil
+}
+
+// DecodeXPFF decrypts a hex string produced by GenerateXPFF.
+func (x *XPFFHeaderGenerator) DecodeXPFF(hexString, guestID string) (string, error) {
+	key := x.deriveXPFFKey(guestID)
+
+	raw, err := hex.DecodeString(hexString)
+	if err != nil {
+		return "", err
+	}
+
+	if len(raw) < 12 {
+		return "", fmt.Errorf("invalid encrypted data")
+	}
+
+	nonce := raw[:12]
+	ciphertext := raw[12:]
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := aesgcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// GetXPFF builds the X-Xp-Forwarded-For header value for guestID.
+func GetXPFF(guestID string) string {
+	baseKey := "0e6be1f1e21ffc33590b888fd4dc81b19713e570e805d4e5df80a493c9571a05"
+	xpffPlain := `{"navigator_properties":{"hasBeenActive":"true","userAgent":"Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko)","webdriver":"false"},"created_at":1750014202073}`
+
+	gen := XPFFHeaderGenerator{BaseKey: baseKey}
+
+	encrypted, err := gen.GenerateXPFF(xpffPlain, guestID)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("Encrypted:", encrypted)
+	return encrypted
+}
+
+// GetCT0Cookie opens Twitter/X, injects auth_token, and returns ct0 cookie
+func GetCT0Cookie(authToken string) (string, error) {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", false), // Set to true if you want headless
+		chromedp.Flag("disable-gpu", false),
+	)
+
+	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	defer cancel()
+
+	ctx, cancelCtx := chromedp.NewContext(allocCtx)
+	defer cancelCtx()
+
+	// Start Chrome
+	if err := chromedp.Run(ctx); err != nil {
+		return "", err
+	}
+
+	// Enable network
+	if err := chromedp.Run(ctx, network.Enable()); err != nil {
+		return "", err
+	}
+
+	// 1️⃣ Navigate to x.com to get domain context
+	if err := chromedp.Run(ctx, chromedp.Navigate("https://x.com")); err != nil {
+		return "", err
+	}
+	time.Sleep(3 * time.Second)
+
+	// 2️⃣ Inject auth_token cookie
+	err := chromedp.Run(ctx,
+		network.SetCookie("auth_token", authToken).
+			WithDomain(".x.com").
+			WithPath("/").
+			WithHTTPOnly(true).
+			WithSecure(true),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	// 3️⃣ Reload to apply cookie
+	if err := chromedp.Run(ctx, chromedp.Reload()); err != nil {
+		return "", err
+	}
+	time.Sleep(5 * time.Second)
+
+	// 4️⃣ Retrieve cookies
+	var cookies []*network.Cookie
+	err = chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		c, e := network.GetCookies().Do(ctx)
+		cookies = c
+		return e
+	}))
+	if err != nil {
+		return "", err
+	}
+
+	// 5️⃣ Find ct0 cookie
+	for _, c := range cookies {
+		if c.Name == "ct0" {
+			return c.Value, nil
+		}
+	}
+
+	return "", fmt.Errorf("ct0 cookie not found")
+}
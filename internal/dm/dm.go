@@ -0,0 +1,196 @@
+// Package dm wraps X's direct-message endpoints (send, inbox, conversation
+// history, event polling) behind one goroutine-friendly client type, so a
+// long-running poll loop can share one client instance with the request
+// handlers.
+package dm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hichbnm/X_bot_api/internal/sessions"
+)
+
+// Entity captures the urls/media/mentions attached to a DM, mirroring the
+// shape X's GraphQL/REST responses embed on every message.
+type Entity struct {
+	URLs     []string `json:"urls,omitempty"`
+	Media    []string `json:"media,omitempty"`
+	Mentions []string `json:"mentions,omitempty"`
+}
+
+// Message is a single normalized direct message.
+type Message struct {
+	ID             string    `json:"id"`
+	ConversationID string    `json:"conversation_id"`
+	SenderID       string    `json:"sender_id"`
+	Text           string    `json:"text"`
+	Entities       Entity    `json:"entities"`
+	SentAt         time.Time `json:"sent_at"`
+}
+
+// Conversation is one DM thread along with its last message and the cursor
+// to fetch older history.
+type Conversation struct {
+	ID             string   `json:"id"`
+	ParticipantIDs []string `json:"participant_ids"`
+	LastMessage    *Message `json:"last_message,omitempty"`
+	Cursor         string   `json:"cursor,omitempty"`
+}
+
+// Event is one item out of the user_updates long-poll stream: a new message,
+// a typing indicator, or a read receipt.
+type Event struct {
+	Type           string   `json:"type"` // "message", "typing", or "read_receipt"
+	Message        *Message `json:"message,omitempty"`
+	ConversationID string   `json:"conversation_id,omitempty"`
+	UserID         string   `json:"user_id,omitempty"`
+}
+
+// Client holds the session used to talk to the DM endpoints.
+type Client struct {
+	session *sessions.Session
+}
+
+// NewClient builds a Client from an already-resolved account session.
+func NewClient(session *sessions.Session) *Client {
+	return &Client{session: session}
+}
+
+// do sends one DM request through sessions.Do, so a 401 re-scrapes the
+// bearer token and retries once instead of failing the whole call. Headers
+// are built through sessions.Headers, the same as tweets.Service and
+// media.Uploader, so an oauth1-mode account gets an OAuth1 signature here
+// too instead of an empty cookie/bearer header set.
+func (c *Client) do(method, targetURL string, body []byte) (int, []byte, error) {
+	return sessions.Do(c.session, method, targetURL, body, func() (map[string]string, error) {
+		return sessions.Headers(c.session, method, targetURL, "https://x.com/messages", len(body), nil)
+	})
+}
+
+// sendMessageRequest is the body POST dm/new2.json expects. recipient_ids
+// (rather than a fabricated conversation_id) lets X resolve or create the
+// 1:1 conversation itself, since the client has no reliable way to know the
+// authed user's own id ahead of time.
+type sendMessageRequest struct {
+	RecipientIDs []string `json:"recipient_ids"`
+	Text         string   `json:"text"`
+	MediaID      string   `json:"media_id,omitempty"`
+}
+
+// SendMessage posts text (and, optionally, one attached media id) into the
+// conversation with recipientID.
+func (c *Client) SendMessage(recipientID, text, mediaID string) (*Message, error) {
+	body, err := json.Marshal(sendMessageRequest{
+		RecipientIDs: []string{recipientID},
+		Text:         text,
+		MediaID:      mediaID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dm/new2 request: %w", err)
+	}
+
+	_, resp, err := c.do("POST", "https://x.com/i/api/1.1/dm/new2.json", body)
+	if err != nil {
+		return nil, fmt.Errorf("dm/new2 request failed: %w", err)
+	}
+
+	var msg Message
+	if err := json.Unmarshal(resp, &msg); err != nil {
+		return nil, fmt.Errorf("failed to parse dm/new2 response: %w", err)
+	}
+	return &msg, nil
+}
+
+// Inbox returns the conversation list along with the cursor for the next page.
+func (c *Client) Inbox() ([]Conversation, string, error) {
+	_, resp, err := c.do("GET", "https://x.com/i/api/1.1/dm/inbox_initial_state.json", nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("inbox_initial_state request failed: %w", err)
+	}
+
+	var parsed struct {
+		Conversations []Conversation `json:"conversations"`
+		Cursor        string         `json:"cursor"`
+	}
+	if err := json.Unmarshal(resp, &parsed); err != nil {
+		return nil, "", fmt.Errorf("failed to parse inbox_initial_state response: %w", err)
+	}
+
+	return parsed.Conversations, parsed.Cursor, nil
+}
+
+// Conversation returns the messages in conversationID, paginating backwards
+// from maxID when set.
+func (c *Client) Conversation(conversationID, maxID string) ([]Message, error) {
+	endpoint := "https://x.com/i/api/1.1/dm/conversation/" + url.PathEscape(conversationID) + ".json"
+	if maxID != "" {
+		endpoint += "?max_id=" + url.QueryEscape(maxID)
+	}
+
+	_, resp, err := c.do("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("conversation request failed: %w", err)
+	}
+
+	var parsed struct {
+		Messages []Message `json:"messages"`
+	}
+	if err := json.Unmarshal(resp, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse conversation response: %w", err)
+	}
+
+	return parsed.Messages, nil
+}
+
+// PollEvents performs one long-poll request against user_updates.json and
+// returns whatever new messages, typing indicators, or read receipts it
+// reports, along with the cursor to pass on the next call.
+func (c *Client) PollEvents(cursor string) ([]Event, string, error) {
+	endpoint := "https://x.com/i/api/1.1/dm/user_updates.json?cursor=" + url.QueryEscape(cursor)
+
+	_, resp, err := c.do("GET", endpoint, nil)
+	if err != nil {
+		return nil, cursor, fmt.Errorf("user_updates request failed: %w", err)
+	}
+
+	var parsed struct {
+		Events []Event `json:"events"`
+		Cursor string  `json:"cursor"`
+	}
+	if err := json.Unmarshal(resp, &parsed); err != nil {
+		return nil, cursor, fmt.Errorf("failed to parse user_updates response: %w", err)
+	}
+
+	return parsed.Events, parsed.Cursor, nil
+}
+
+// RunEventLoop calls PollEvents in a loop, forwarding every event onto out,
+// until ctx is cancelled. Callers run it with `go client.RunEventLoop(...)`.
+func (c *Client) RunEventLoop(ctx context.Context, cursor string, out chan<- Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		events, next, err := c.PollEvents(cursor)
+		if err != nil {
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		cursor = next
+
+		for _, event := range events {
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Package media drives the three-step INIT/APPEND/FINALIZE chunked upload
+// protocol upload.twitter.com expects, polling STATUS afterwards for any
+// category that needs backend processing (video, gif).
+package media
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hichbnm/X_bot_api/internal/sessions"
+)
+
+const (
+	mediaUploadURL = "https://upload.twitter.com/i/media/upload.json"
+	mediaChunkSize = 4 * 1024 * 1024 // 4 MB per APPEND, per X's docs
+)
+
+// Uploader drives the upload for one session.
+type Uploader struct {
+	session *sessions.Session
+}
+
+// NewUploader builds an Uploader that signs every call as session.
+func NewUploader(session *sessions.Session) *Uploader {
+	return &Uploader{session: session}
+}
+
+type initResponse struct {
+	MediaIDString string `json:"media_id_string"`
+}
+
+// ProcessingInfo is the processing_info block on an INIT/STATUS response.
+type ProcessingInfo struct {
+	State          string `json:"state"`
+	CheckAfterSecs int    `json:"check_after_secs"`
+	Error          *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type statusResponse struct {
+	ProcessingInfo *ProcessingInfo `json:"processing_info,omitempty"`
+}
+
+type finalizeResponse struct {
+	ExpiresAfterSecs int `json:"expires_after_secs"`
+}
+
+// Upload runs the full INIT/APPEND/FINALIZE(/STATUS) dance for data tagged
+// as category (one of tweet_image, tweet_video, tweet_gif, dm_image,
+// dm_video) and returns the resulting media id along with how many seconds
+// X says the upload is valid for before it must be attached to a tweet/DM.
+func (u *Uploader) Upload(data []byte, mediaType, category string) (mediaID string, expiresAfterSecs int, err error) {
+	mediaID, err = u.init(len(data), mediaType, category)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if err := u.appendChunks(mediaID, data); err != nil {
+		return "", 0, err
+	}
+
+	expiresAfterSecs, err = u.finalize(mediaID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if category == "tweet_video" || category == "dm_video" || category == "tweet_gif" {
+		if err := u.awaitProcessing(mediaID); err != nil {
+			return "", 0, err
+		}
+	}
+
+	return mediaID, expiresAfterSecs, nil
+}
+
+func (u *Uploader) headers(params map[string]string) (map[string]string, error) {
+	return sessions.Headers(u.session, http.MethodPost, mediaUploadURL, "https://x.com/", 0, params)
+}
+
+// do runs one media upload request through sessions.Do, so a 401 on a
+// cookie-mode session re-scrapes the bearer token and retries once instead
+// of failing the whole upload.
+func (u *Uploader) do(targetURL string, body []byte, params map[string]string, contentType string) (int, []byte, error) {
+	return sessions.Do(u.session, "POST", targetURL, body, func() (map[string]string, error) {
+		headers, err := u.headers(params)
+		if err != nil {
+			return nil, err
+		}
+		headers["Content-Type"] = contentType
+		return headers, nil
+	})
+}
+
+func (u *Uploader) init(totalBytes int, mediaType, category string) (string, error) {
+	form := url.Values{
+		"command":        {"INIT"},
+		"total_bytes":    {strconv.Itoa(totalBytes)},
+		"media_type":     {mediaType},
+		"media_category": {category},
+	}
+
+	status, body, err := u.do(mediaUploadURL, []byte(form.Encode()), flatten(form), "application/x-www-form-urlencoded")
+	if err != nil {
+		return "", err
+	}
+	if status >= 400 {
+		return "", fmt.Errorf("media INIT failed with status %d: %s", status, body)
+	}
+
+	var parsed initResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse INIT response: %w", err)
+	}
+
+	return parsed.MediaIDString, nil
+}
+
+func (u *Uploader) appendChunks(mediaID string, data []byte) error {
+	for segmentIndex, offset := 0, 0; offset < len(data); segmentIndex, offset = segmentIndex+1, offset+mediaChunkSize {
+		end := offset + mediaChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		if err := u.appendChunk(mediaID, segmentIndex, data[offset:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *Uploader) appendChunk(mediaID string, segmentIndex int, chunk []byte) error {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	writer.WriteField("command", "APPEND")
+	writer.WriteField("media_id", mediaID)
+	writer.WriteField("segment_index", strconv.Itoa(segmentIndex))
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="media"; filename="chunk"`},
+		"Content-Type":        {"application/octet-stream"},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(chunk); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	// The body is multipart, which OAuth1 never signs, but "command" still
+	// rides along as a query param on the URL itself and must be in the base
+	// string.
+	status, body, err := u.do(mediaUploadURL+"?command=APPEND", buf.Bytes(), map[string]string{"command": "APPEND"}, writer.FormDataContentType())
+	if err != nil {
+		return err
+	}
+	if status >= 300 {
+		return fmt.Errorf("media APPEND segment %d failed with status %d: %s", segmentIndex, status, body)
+	}
+
+	return nil
+}
+
+// finalize returns the expires_after_secs X reports the media id is valid
+// for before it must be attached to a tweet/DM.
+func (u *Uploader) finalize(mediaID string) (int, error) {
+	form := url.Values{"command": {"FINALIZE"}, "media_id": {mediaID}}
+
+	status, body, err := u.do(mediaUploadURL, []byte(form.Encode()), flatten(form), "application/x-www-form-urlencoded")
+	if err != nil {
+		return 0, err
+	}
+	if status >= 400 {
+		return 0, fmt.Errorf("media FINALIZE failed with status %d: %s", status, body)
+	}
+
+	var parsed finalizeResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return 0, fmt.Errorf("failed to parse FINALIZE response: %w", err)
+	}
+
+	return parsed.ExpiresAfterSecs, nil
+}
+
+// awaitProcessing polls STATUS until X reports the upload succeeded or
+// failed, sleeping the check_after_secs it asks for between polls.
+func (u *Uploader) awaitProcessing(mediaID string) error {
+	for {
+		endpoint := mediaUploadURL + "?command=STATUS&media_id=" + url.QueryEscape(mediaID)
+		status, body, err := sessions.Do(u.session, "GET", endpoint, nil, func() (map[string]string, error) {
+			return u.headers(map[string]string{"command": "STATUS", "media_id": mediaID})
+		})
+		if err != nil {
+			return err
+		}
+		if status >= 400 {
+			return fmt.Errorf("media STATUS failed with status %d: %s", status, body)
+		}
+
+		var parsed statusResponse
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return fmt.Errorf("failed to parse STATUS response: %w", err)
+		}
+
+		if parsed.ProcessingInfo == nil || parsed.ProcessingInfo.State == "succeeded" {
+			return nil
+		}
+		if parsed.ProcessingInfo.State == "failed" {
+			msg := "unknown error"
+			if parsed.ProcessingInfo.Error != nil {
+				msg = parsed.ProcessingInfo.Error.Message
+			}
+			return fmt.Errorf("media processing failed: %s", msg)
+		}
+
+		wait := parsed.ProcessingInfo.CheckAfterSecs
+		if wait <= 0 {
+			wait = 1
+		}
+		time.Sleep(time.Duration(wait) * time.Second)
+	}
+}
+
+// CategoryForContentType picks the media_type/media_category X expects from
+// an uploaded file's declared content type, defaulting to the DM variants
+// when forDM is set.
+func CategoryForContentType(contentType string, forDM bool) (mediaType string, category string) {
+	switch {
+	case strings.HasPrefix(contentType, "video/"):
+		if forDM {
+			return "video/mp4", "dm_video"
+		}
+		return "video/mp4", "tweet_video"
+	case contentType == "image/gif":
+		return "image/gif", "tweet_gif"
+	default:
+		if forDM {
+			return "image/jpeg", "dm_image"
+		}
+		return "image/jpeg", "tweet_image"
+	}
+}
+
+// flatten collapses a url.Values into the single-valued map the OAuth1
+// signer expects, keeping each key's first value (INIT/FINALIZE never repeat
+// a key).
+func flatten(form url.Values) map[string]string {
+	params := make(map[string]string, len(form))
+	for k, v := range form {
+		if len(v) > 0 {
+			params[k] = v[0]
+		}
+	}
+	return params
+}
@@ -0,0 +1,55 @@
+package media
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestCategoryForContentType(t *testing.T) {
+	cases := []struct {
+		contentType   string
+		forDM         bool
+		wantMediaType string
+		wantCategory  string
+	}{
+		{"video/mp4", false, "video/mp4", "tweet_video"},
+		{"video/mp4", true, "video/mp4", "dm_video"},
+		{"video/quicktime", false, "video/mp4", "tweet_video"},
+		{"image/gif", false, "image/gif", "tweet_gif"},
+		{"image/gif", true, "image/gif", "tweet_gif"},
+		{"image/jpeg", false, "image/jpeg", "tweet_image"},
+		{"image/jpeg", true, "image/jpeg", "dm_image"},
+		{"image/png", false, "image/jpeg", "tweet_image"},
+	}
+
+	for _, c := range cases {
+		mediaType, category := CategoryForContentType(c.contentType, c.forDM)
+		if mediaType != c.wantMediaType || category != c.wantCategory {
+			t.Errorf("CategoryForContentType(%q, %v) = (%q, %q), want (%q, %q)",
+				c.contentType, c.forDM, mediaType, category, c.wantMediaType, c.wantCategory)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	form := url.Values{
+		"command":  {"INIT"},
+		"media_id": {"12345"},
+	}
+
+	got := flatten(form)
+	if got["command"] != "INIT" || got["media_id"] != "12345" {
+		t.Errorf("flatten() = %v, want command=INIT media_id=12345", got)
+	}
+	if len(got) != 2 {
+		t.Errorf("flatten() returned %d keys, want 2", len(got))
+	}
+}
+
+func TestFlattenSkipsEmptyValues(t *testing.T) {
+	form := url.Values{"empty": {}}
+
+	if got := flatten(form); len(got) != 0 {
+		t.Errorf("flatten() with an empty value list = %v, want no entries", got)
+	}
+}
@@ -0,0 +1,60 @@
+package sessions
+
+import (
+	"fmt"
+
+	"github.com/hichbnm/X_bot_api/internal/auth"
+	"github.com/hichbnm/X_bot_api/internal/oauth1"
+)
+
+// Headers builds the header set every x.com GraphQL/REST call needs: the
+// usual browser-ish headers plus either cookie/CSRF/XPFF or OAuth1 signing,
+// depending on session.AuthMode. Shared by the tweets, dm, and media
+// packages so every upstream call is signed the same way. params must hold
+// every query-string and application/x-www-form-urlencoded body parameter
+// on the request (nil for a JSON or multipart body, neither of which OAuth1
+// signs) — oauth1.BuildAuthorizationHeader needs them to compute a correct
+// signature.
+func Headers(session *Session, method, targetURL, referer string, bodyLen int, params map[string]string) (map[string]string, error) {
+	headers := map[string]string{
+		"Host":                      "x.com",
+		"Sec-Ch-Ua-Platform":        "\"Linux\"",
+		"Accept-Language":           "en-US,en;q=0.9",
+		"Sec-Ch-Ua":                 "\"Not)A;Brand\";v=\"8\", \"Chromium\";v=\"138\"",
+		"X-Twitter-Client-Language": "en",
+		"Sec-Ch-Ua-Mobile":          "?0",
+		"X-Twitter-Active-User":     "yes",
+		"User-Agent":                "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/138.0.0.0 Safari/537.36",
+		"Content-Type":              "application/json",
+		"Accept":                    "*/*",
+		"Origin":                    "https://x.com",
+		"Sec-Fetch-Site":            "same-origin",
+		"Sec-Fetch-Mode":            "cors",
+		"Sec-Fetch-Dest":            "empty",
+		"Referer":                   referer,
+		"Priority":                  "u=1, i",
+	}
+	if bodyLen > 0 {
+		headers["Content-Length"] = fmt.Sprint(bodyLen)
+	}
+
+	if session.AuthMode == "oauth1" {
+		if session.OAuth1 == nil {
+			return nil, fmt.Errorf("session %s has auth_mode=oauth1 but no oauth1 credentials set", session.AccountID)
+		}
+
+		authHeader, err := oauth1.BuildAuthorizationHeader(method, targetURL, params, *session.OAuth1)
+		if err != nil {
+			return nil, err
+		}
+		headers["Authorization"] = authHeader
+	} else {
+		headers["Cookie"] = "auth_token=" + session.AuthToken + "; ct0=" + session.CT0
+		headers["Authorization"] = "Bearer " + session.BearerToken
+		headers["X-Csrf-Token"] = session.CT0
+		headers["X-Twitter-Auth-Type"] = "OAuth2Session"
+		headers["X-Xp-Forwarded-For"] = auth.GetXPFF(session.GuestID)
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,54 @@
+package sessions
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hichbnm/X_bot_api/internal/auth"
+	"github.com/hichbnm/X_bot_api/internal/xhttp"
+)
+
+// Do sends one request through xhttp using whatever buildHeaders returns,
+// then — for cookie-mode sessions only — re-scrapes the bearer token and
+// retries once if X responds 401. buildHeaders is called again after the
+// refresh so the retry picks up session's new token; oauth1-mode sessions
+// never hold a bearer token, so a 401 there is passed straight through.
+func Do(session *Session, method, targetURL string, body []byte, buildHeaders func() (map[string]string, error)) (int, []byte, error) {
+	headers, err := buildHeaders()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	status, respBody, err := xhttp.RequestStatus(targetURL, method, body, headers)
+	if err != nil || status != http.StatusUnauthorized || session.AuthMode == "oauth1" {
+		return status, respBody, err
+	}
+
+	if refreshErr := refreshBearerToken(session); refreshErr != nil {
+		return status, respBody, nil
+	}
+
+	headers, err = buildHeaders()
+	if err != nil {
+		return 0, nil, err
+	}
+	return xhttp.RequestStatus(targetURL, method, body, headers)
+}
+
+// refreshBearerToken re-scrapes a bearer token and persists it onto session,
+// for the 401 retry in Do above.
+func refreshBearerToken(session *Session) error {
+	token, err := auth.RefreshBearerToken(true)
+	if err != nil {
+		return err
+	}
+
+	session.BearerToken = token
+	session.BearerFetchedAt = time.Now()
+
+	repo, err := EnsureRepo()
+	if err != nil {
+		return err
+	}
+	return repo.Save(session)
+}
@@ -0,0 +1,332 @@
+// Package sessions stores and resolves per-account credentials: the cookies
+// and bearer token used by the default "cookie" auth mode, or the OAuth1
+// credentials used by "oauth1" mode.
+package sessions
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+
+	"github.com/hichbnm/X_bot_api/internal/oauth1"
+)
+
+// Session is everything needed to act as one X account: its cookies, its
+// current bearer token, and bookkeeping on when each was last refreshed.
+type Session struct {
+	AccountID       string    `json:"account_id"`
+	AuthToken       string    `json:"auth_token"`
+	GuestID         string    `json:"guest_id"`
+	CT0             string    `json:"ct0"`
+	CT0FetchedAt    time.Time `json:"ct0_fetched_at"`
+	BearerToken     string    `json:"bearer_token"`
+	BearerFetchedAt time.Time `json:"bearer_fetched_at"`
+	UserAgent       string    `json:"user_agent"`
+	XPFFBaseKey     string    `json:"xpff_base_key,omitempty"`
+	LastUsed        time.Time `json:"last_used"`
+
+	// AuthMode picks how requests made with this session are signed:
+	// "cookie" (default, auth_token/ct0 scraped via chromedp) or "oauth1"
+	// (OAuth1 below, no browser automation required).
+	AuthMode string              `json:"auth_mode,omitempty"`
+	OAuth1   *oauth1.Credentials `json:"oauth1,omitempty"`
+}
+
+// Repo stores one Session per X account.
+type Repo interface {
+	Get(accountID string) (*Session, error)
+	Save(session *Session) error
+	List() ([]string, error)
+	Delete(accountID string) error
+}
+
+// sessionsDir holds one encrypted JSON file per account, replacing the old
+// flat auth_token.txt / guest_id.txt.
+const sessionsDir = "./data/sessions"
+
+// FileRepo persists each Session as an AES-GCM encrypted JSON blob.
+type FileRepo struct {
+	key []byte
+}
+
+// NewFileRepo derives the encryption key from the SESSION_ENCRYPTION_KEY env
+// var (SHA-256, same derivation auth.XPFFHeaderGenerator.deriveXPFFKey uses
+// for its own key) and ensures sessionsDir exists.
+func NewFileRepo() (*FileRepo, error) {
+	passphrase := os.Getenv("SESSION_ENCRYPTION_KEY")
+	if passphrase == "" {
+		return nil, fmt.Errorf("SESSION_ENCRYPTION_KEY is not set")
+	}
+
+	if err := os.MkdirAll(sessionsDir, 0700); err != nil {
+		return nil, err
+	}
+
+	key := sha256.Sum256([]byte(passphrase))
+	return &FileRepo{key: key[:]}, nil
+}
+
+func (repo *FileRepo) path(accountID string) string {
+	return filepath.Join(sessionsDir, accountID+".json")
+}
+
+func (repo *FileRepo) encrypt(plaintext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 12)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(nonce, aesgcm.Seal(nil, nonce, plaintext, nil)...), nil
+}
+
+func (repo *FileRepo) decrypt(data []byte) ([]byte, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("invalid encrypted session")
+	}
+
+	block, err := aes.NewCipher(repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return aesgcm.Open(nil, data[:12], data[12:], nil)
+}
+
+func (repo *FileRepo) Get(accountID string) (*Session, error) {
+	data, err := os.ReadFile(repo.path(accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := repo.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(plaintext, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (repo *FileRepo) Save(session *Session) error {
+	plaintext, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := repo.encrypt(plaintext)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(repo.path(session.AccountID), ciphertext, 0600)
+}
+
+func (repo *FileRepo) List() ([]string, error) {
+	entries, err := os.ReadDir(sessionsDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			ids = append(ids, strings.TrimSuffix(entry.Name(), ".json"))
+		}
+	}
+	return ids, nil
+}
+
+func (repo *FileRepo) Delete(accountID string) error {
+	return os.Remove(repo.path(accountID))
+}
+
+// repo is the process-wide repo every handler routes through.
+var repo Repo
+
+// EnsureRepo returns the process-wide Repo, constructing it on first use.
+func EnsureRepo() (Repo, error) {
+	if repo != nil {
+		return repo, nil
+	}
+
+	fileRepo, err := NewFileRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	repo = fileRepo
+	return repo, nil
+}
+
+// ForRequest resolves the account a caller wants to act as from the
+// X-Account-Id header.
+func ForRequest(r *http.Request) (*Session, error) {
+	accountID := r.Header.Get("X-Account-Id")
+	if accountID == "" {
+		return nil, fmt.Errorf("missing X-Account-Id header")
+	}
+
+	repo, err := EnsureRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := repo.Get(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("unknown account %q: %w", accountID, err)
+	}
+
+	session.LastUsed = time.Now()
+	repo.Save(session)
+
+	return session, nil
+}
+
+// FetchAccountCookies drives the same headful-chromedp login dance
+// auth.GetCT0Cookie uses, but captures both ct0 and guest_id in one pass.
+func FetchAccountCookies(authToken string) (ct0 string, guestID string, err error) {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", false),
+		chromedp.Flag("disable-gpu", false),
+	)
+
+	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	defer cancel()
+
+	ctx, cancelCtx := chromedp.NewContext(allocCtx)
+	defer cancelCtx()
+
+	if err := chromedp.Run(ctx); err != nil {
+		return "", "", err
+	}
+	if err := chromedp.Run(ctx, network.Enable()); err != nil {
+		return "", "", err
+	}
+	if err := chromedp.Run(ctx, chromedp.Navigate("https://x.com")); err != nil {
+		return "", "", err
+	}
+	time.Sleep(3 * time.Second)
+
+	err = chromedp.Run(ctx,
+		network.SetCookie("auth_token", authToken).
+			WithDomain(".x.com").
+			WithPath("/").
+			WithHTTPOnly(true).
+			WithSecure(true),
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := chromedp.Run(ctx, chromedp.Reload()); err != nil {
+		return "", "", err
+	}
+	time.Sleep(5 * time.Second)
+
+	var cookies []*network.Cookie
+	err = chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		c, e := network.GetCookies().Do(ctx)
+		cookies = c
+		return e
+	}))
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, c := range cookies {
+		switch c.Name {
+		case "ct0":
+			ct0 = c.Value
+		case "guest_id":
+			guestID = c.Value
+		}
+	}
+
+	if ct0 == "" {
+		return "", "", fmt.Errorf("ct0 cookie not found")
+	}
+	if guestID == "" {
+		return "", "", fmt.Errorf("guest_id cookie not found")
+	}
+
+	return ct0, guestID, nil
+}
+
+// StartRefreshJob re-fetches ct0 for every stored account whose cookie is
+// older than 24h, so long-running deployments don't drift.
+func StartRefreshJob() {
+	go func() {
+		for {
+			time.Sleep(1 * time.Hour)
+
+			repo, err := EnsureRepo()
+			if err != nil {
+				continue
+			}
+
+			ids, err := repo.List()
+			if err != nil {
+				continue
+			}
+
+			for _, id := range ids {
+				session, err := repo.Get(id)
+				if err != nil {
+					continue
+				}
+
+				if session.AuthMode == "oauth1" {
+					continue
+				}
+				if time.Since(session.CT0FetchedAt) < 24*time.Hour {
+					continue
+				}
+
+				ct0, _, err := FetchAccountCookies(session.AuthToken)
+				if err != nil {
+					fmt.Println("⚠️ Failed to refresh ct0 for", id, ":", err)
+					continue
+				}
+
+				session.CT0 = ct0
+				session.CT0FetchedAt = time.Now()
+				repo.Save(session)
+			}
+		}
+	}()
+}
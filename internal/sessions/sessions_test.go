@@ -0,0 +1,53 @@
+package sessions
+
+import "testing"
+
+func TestFileRepoEncryptDecryptRoundTrip(t *testing.T) {
+	repo := &FileRepo{key: make([]byte, 32)}
+	for i := range repo.key {
+		repo.key[i] = byte(i)
+	}
+
+	plaintext := []byte(`{"account_id":"demo","auth_token":"secret-token"}`)
+
+	ciphertext, err := repo.encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("encrypt returned error: %v", err)
+	}
+	if string(ciphertext) == string(plaintext) {
+		t.Fatal("encrypt returned plaintext unchanged")
+	}
+
+	got, err := repo.decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("decrypt returned error: %v", err)
+	}
+	if string(got) != string(plaintext) {
+		t.Errorf("decrypt() = %q, want %q", got, plaintext)
+	}
+}
+
+func TestFileRepoEncryptIsRandomized(t *testing.T) {
+	repo := &FileRepo{key: make([]byte, 32)}
+
+	a, err := repo.encrypt([]byte("same plaintext"))
+	if err != nil {
+		t.Fatalf("encrypt returned error: %v", err)
+	}
+	b, err := repo.encrypt([]byte("same plaintext"))
+	if err != nil {
+		t.Fatalf("encrypt returned error: %v", err)
+	}
+
+	if string(a) == string(b) {
+		t.Error("encrypt produced identical ciphertext for two calls; nonce isn't being randomized")
+	}
+}
+
+func TestFileRepoDecryptRejectsTooShort(t *testing.T) {
+	repo := &FileRepo{key: make([]byte, 32)}
+
+	if _, err := repo.decrypt([]byte("short")); err == nil {
+		t.Error("decrypt of a too-short blob should fail, got nil error")
+	}
+}
@@ -0,0 +1,208 @@
+// Package tweets wraps the tweet-shaped GraphQL calls (create, reply, quote,
+// retweet, favorite, delete, lookup) and the UserByScreenName REST-over-
+// GraphQL call behind one session-scoped service.
+package tweets
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hichbnm/X_bot_api/internal/sessions"
+)
+
+// graphqlOp is one GraphQL operation's name, queryId, and HTTP verb. X mints
+// a new queryId whenever it ships a bundle that touches the operation, but
+// the name stays stable, so a table like this is the only thing that needs
+// updating when one rotates.
+type graphqlOp struct {
+	Name    string
+	QueryID string
+	Method  string
+}
+
+func (op graphqlOp) url() string {
+	return "https://x.com/i/api/graphql/" + op.QueryID + "/" + op.Name
+}
+
+var (
+	opCreateTweet         = graphqlOp{"CreateTweet", "F7hteriqzdRzvMfXM6Ul4w", "POST"}
+	opDeleteTweet         = graphqlOp{"DeleteTweet", "VaenaVgh5q5ih7kvyVjgtg", "POST"}
+	opCreateRetweet       = graphqlOp{"CreateRetweet", "ojPdsZsimiJrUGLR1sjUtA", "POST"}
+	opDeleteRetweet       = graphqlOp{"DeleteRetweet", "iQtK4dl5hBmXewYZuEOKVw", "POST"}
+	opFavoriteTweet       = graphqlOp{"FavoriteTweet", "lI07N6Otwv1PhnEgXILM7A", "POST"}
+	opUnfavoriteTweet     = graphqlOp{"UnfavoriteTweet", "ZYKSe-w7KEslx3JhSIk5LA", "POST"}
+	opTweetResultByRestID = graphqlOp{"TweetResultByRestId", "zAxTKSNIopnhQRn-gfnVCA", "GET"}
+	opUserByScreenName    = graphqlOp{"UserByScreenName", "G3KGOASz96M-Qu0nwmGXNg", "GET"}
+)
+
+// defaultFeatures is the feature-flag blob X's web client sends on (almost)
+// every GraphQL request. It used to be pasted inline into replyToTweet;
+// every operation in TweetService now shares this one copy instead.
+func defaultFeatures() map[string]bool {
+	return map[string]bool{
+		"premium_content_api_read_enabled":                                        false,
+		"communities_web_enable_tweet_community_results_fetch":                    true,
+		"c9s_tweet_anatomy_moderator_badge_enabled":                               true,
+		"responsive_web_grok_analyze_button_fetch_trends_enabled":                 false,
+		"responsive_web_grok_analyze_post_followups_enabled":                      true,
+		"responsive_web_jetfuel_frame":                                            true,
+		"responsive_web_grok_share_attachment_enabled":                            true,
+		"responsive_web_edit_tweet_api_enabled":                                   true,
+		"graphql_is_translatable_rweb_tweet_is_translatable_enabled":              true,
+		"view_counts_everywhere_api_enabled":                                      true,
+		"longform_notetweets_consumption_enabled":                                 true,
+		"responsive_web_twitter_article_tweet_consumption_enabled":                true,
+		"tweet_awards_web_tipping_enabled":                                        false,
+		"responsive_web_grok_show_grok_translated_post":                           false,
+		"responsive_web_grok_analysis_button_from_backend":                        true,
+		"creator_subscriptions_quote_tweet_preview_enabled":                       false,
+		"longform_notetweets_rich_text_read_enabled":                              true,
+		"longform_notetweets_inline_media_enabled":                                true,
+		"payments_enabled":                                                        false,
+		"profile_label_improvements_pcf_label_in_post_enabled":                    true,
+		"rweb_tipjar_consumption_enabled":                                         true,
+		"verified_phone_label_enabled":                                            false,
+		"articles_preview_enabled":                                                true,
+		"responsive_web_grok_community_note_auto_translation_is_enabled":          false,
+		"responsive_web_graphql_skip_user_profile_image_extensions_enabled":       false,
+		"freedom_of_speech_not_reach_fetch_enabled":                               true,
+		"standardized_nudges_misinfo":                                             true,
+		"tweet_with_visibility_results_prefer_gql_limited_actions_policy_enabled": true,
+		"responsive_web_grok_image_annotation_enabled":                            true,
+		"responsive_web_graphql_timeline_navigation_enabled":                      true,
+		"responsive_web_enhance_cards_enabled":                                    false,
+	}
+}
+
+// Service wraps every tweet-shaped GraphQL call behind one session.
+type Service struct {
+	session *sessions.Session
+}
+
+// NewService builds a Service that signs every call as session.
+func NewService(session *sessions.Session) *Service {
+	return &Service{session: session}
+}
+
+// do executes op with variables, returning X's raw HTTP status and body.
+func (s *Service) do(op graphqlOp, variables map[string]interface{}) (int, []byte, error) {
+	targetURL := op.url()
+	var body []byte
+	var queryParams map[string]string
+
+	if op.Method == http.MethodGet {
+		variablesJSON, err := json.Marshal(variables)
+		if err != nil {
+			return 0, nil, err
+		}
+		featuresJSON, err := json.Marshal(defaultFeatures())
+		if err != nil {
+			return 0, nil, err
+		}
+		queryParams = map[string]string{
+			"variables": string(variablesJSON),
+			"features":  string(featuresJSON),
+		}
+		targetURL += "?variables=" + url.QueryEscape(string(variablesJSON)) + "&features=" + url.QueryEscape(string(featuresJSON))
+	} else {
+		payload := map[string]interface{}{
+			"variables": variables,
+			"features":  defaultFeatures(),
+			"queryId":   op.QueryID,
+		}
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	// The POST ops send a JSON body, which OAuth1 never signs, so params is
+	// nil there. The GET ops fold variables/features into targetURL's query
+	// string instead of the body, and OAuth1 must sign query params
+	// regardless of body type, so queryParams carries them through here.
+	return sessions.Do(s.session, op.Method, targetURL, body, func() (map[string]string, error) {
+		return sessions.Headers(s.session, op.Method, targetURL, "https://x.com/home", len(body), queryParams)
+	})
+}
+
+// Create posts a new top-level tweet.
+func (s *Service) Create(text string, mediaIDs []string) (int, []byte, error) {
+	return s.do(opCreateTweet, map[string]interface{}{
+		"tweet_text":              text,
+		"media":                   map[string]interface{}{"media_entities": mediaEntities(mediaIDs), "possibly_sensitive": false},
+		"dark_request":            false,
+		"semantic_annotation_ids": []interface{}{},
+	})
+}
+
+// Reply posts text as a reply to inReplyTo.
+func (s *Service) Reply(text, inReplyTo string, mediaIDs []string) (int, []byte, error) {
+	return s.do(opCreateTweet, map[string]interface{}{
+		"tweet_text": text,
+		"reply": map[string]interface{}{
+			"in_reply_to_tweet_id":   inReplyTo,
+			"exclude_reply_user_ids": []string{},
+		},
+		"media":                   map[string]interface{}{"media_entities": mediaEntities(mediaIDs), "possibly_sensitive": false},
+		"dark_request":            false,
+		"semantic_annotation_ids": []interface{}{},
+	})
+}
+
+// Quote posts text as a quote tweet of quotedURL.
+func (s *Service) Quote(text, quotedURL string) (int, []byte, error) {
+	return s.do(opCreateTweet, map[string]interface{}{
+		"tweet_text":              text,
+		"attachment_url":          quotedURL,
+		"dark_request":            false,
+		"semantic_annotation_ids": []interface{}{},
+	})
+}
+
+// Retweet reposts the tweet with the given id.
+func (s *Service) Retweet(id string) (int, []byte, error) {
+	return s.do(opCreateRetweet, map[string]interface{}{"tweet_id": id, "dark_request": false})
+}
+
+// Unretweet undoes a previous Retweet.
+func (s *Service) Unretweet(id string) (int, []byte, error) {
+	return s.do(opDeleteRetweet, map[string]interface{}{"source_tweet_id": id})
+}
+
+// Favorite likes the tweet with the given id.
+func (s *Service) Favorite(id string) (int, []byte, error) {
+	return s.do(opFavoriteTweet, map[string]interface{}{"tweet_id": id})
+}
+
+// Unfavorite undoes a previous Favorite.
+func (s *Service) Unfavorite(id string) (int, []byte, error) {
+	return s.do(opUnfavoriteTweet, map[string]interface{}{"tweet_id": id})
+}
+
+// Delete removes the tweet with the given id.
+func (s *Service) Delete(id string) (int, []byte, error) {
+	return s.do(opDeleteTweet, map[string]interface{}{"tweet_id": id, "dark_request": false})
+}
+
+// Lookup fetches a single tweet by id.
+func (s *Service) Lookup(id string) (int, []byte, error) {
+	return s.do(opTweetResultByRestID, map[string]interface{}{"tweetId": id, "withCommunity": false})
+}
+
+// UserByScreenName fetches a user profile by @handle.
+func (s *Service) UserByScreenName(handle string) (int, []byte, error) {
+	return s.do(opUserByScreenName, map[string]interface{}{"screen_name": strings.TrimPrefix(handle, "@")})
+}
+
+// mediaEntities turns a list of media ids into the media_entities shape
+// CreateTweet expects.
+func mediaEntities(mediaIDs []string) []map[string]interface{} {
+	entities := make([]map[string]interface{}, 0, len(mediaIDs))
+	for _, id := range mediaIDs {
+		entities = append(entities, map[string]interface{}{"media_id": id, "tagged_users": []string{}})
+	}
+	return entities
+}
@@ -0,0 +1,50 @@
+package tweets
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestMediaEntities(t *testing.T) {
+	if got := mediaEntities(nil); len(got) != 0 {
+		t.Errorf("mediaEntities(nil) = %v, want empty slice", got)
+	}
+
+	got := mediaEntities([]string{"111", "222"})
+	if len(got) != 2 {
+		t.Fatalf("mediaEntities() returned %d entries, want 2", len(got))
+	}
+	for i, id := range []string{"111", "222"} {
+		if got[i]["media_id"] != id {
+			t.Errorf("entry %d media_id = %v, want %q", i, got[i]["media_id"], id)
+		}
+		if _, ok := got[i]["tagged_users"]; !ok {
+			t.Errorf("entry %d missing tagged_users key", i)
+		}
+	}
+}
+
+func TestGraphqlOpURL(t *testing.T) {
+	op := graphqlOp{Name: "CreateTweet", QueryID: "abc123", Method: http.MethodPost}
+
+	want := "https://x.com/i/api/graphql/abc123/CreateTweet"
+	if got := op.url(); got != want {
+		t.Errorf("url() = %q, want %q", got, want)
+	}
+}
+
+func TestOpTableMethods(t *testing.T) {
+	posts := []graphqlOp{opCreateTweet, opDeleteTweet, opCreateRetweet, opDeleteRetweet, opFavoriteTweet, opUnfavoriteTweet}
+	for _, op := range posts {
+		if op.Method != http.MethodPost {
+			t.Errorf("%s.Method = %q, want POST", op.Name, op.Method)
+		}
+	}
+
+	gets := []graphqlOp{opTweetResultByRestID, opUserByScreenName}
+	for _, op := range gets {
+		if op.Method != http.MethodGet {
+			t.Errorf("%s.Method = %q, want GET", op.Name, op.Method)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Package xhttp is the thin HTTP client every x.com call goes through: same
+// TLS/transport setup, same header-setting, just two different return shapes
+// depending on whether the caller wants a formatted string or the raw
+// status/body pair.
+package xhttp
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var client = &http.Client{
+	Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	},
+}
+
+// Request performs method against targetURL with data and headers, returning
+// the response body as a string. Errors are returned rather than panicking,
+// so a routine upstream network failure doesn't crash the caller.
+func Request(targetURL, method string, data []byte, headers map[string]string) (string, error) {
+	request, err := http.NewRequest(method, targetURL, bytes.NewBuffer(data))
+	if err != nil {
+		return "", err
+	}
+
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Println("response Status:", response.Status)
+	return string(body), nil
+}
+
+// RequestStatus is like Request but hands back the status code and raw body
+// instead of formatting everything into a bare string, so callers that need
+// to propagate X's HTTP status (the /tweets and /users routes) can do so
+// directly instead of re-wrapping it in another JSON envelope.
+func RequestStatus(targetURL, method string, data []byte, headers map[string]string) (int, []byte, error) {
+	request, err := http.NewRequest(method, targetURL, bytes.NewBuffer(data))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return response.StatusCode, body, nil
+}